@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	checkProxyFlag      string
+	checkUseGatewayFlag bool
+	checkUseLocalhost   bool
+	checkPortFlag       int
+)
+
+var checkCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Probe a proxy server for reachability before committing it everywhere",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		proxyServer, err := resolveProxyServer(checkProxyFlag, checkUseGatewayFlag, checkUseLocalhost, checkPortFlag)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Checking %s...\n", proxyServer)
+		warning, err := probeProxy(proxyServer)
+		if err != nil {
+			return err
+		}
+		if warning != "" {
+			fmt.Println("Warning:", warning)
+			return nil
+		}
+
+		fmt.Println("Proxy is reachable and responded correctly to a test CONNECT.")
+		return nil
+	},
+}
+
+func init() {
+	checkCmd.Flags().StringVar(&checkProxyFlag, "proxy", "", "proxy server as host:port, or the per-protocol form http=host:port;https=host:port")
+	checkCmd.Flags().BoolVar(&checkUseGatewayFlag, "use-gateway", false, "use the default gateway as the proxy host")
+	checkCmd.Flags().BoolVar(&checkUseLocalhost, "use-localhost", false, "use 127.0.0.1 as the proxy host")
+	checkCmd.Flags().IntVar(&checkPortFlag, "port", 10808, "port to use with --use-gateway or --use-localhost")
+}
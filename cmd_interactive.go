@@ -0,0 +1,273 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// interactiveCmd is an explicit fallback into the blocking menu for users who
+// don't want to remember flags; it's also what the bare `wpm` invocation runs.
+var interactiveCmd = &cobra.Command{
+	Use:   "interactive",
+	Short: "Run the interactive proxy menu",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runInteractive()
+	},
+}
+
+// runInteractive contains the original blocking menu loop, routed through the
+// same applyProxySettings core used by the CLI subcommands.
+func runInteractive() error {
+	// lastProfile tracks the most recently applied configuration so
+	// "Save current as profile" has something to save.
+	var lastProfile Profile
+
+	for {
+		// Get and display current proxy status
+		currentProxy, err := getCurrentProxy()
+		gateway, gwErr := getDefaultGateway() // Get gateway to determine tag
+
+		if err != nil {
+			fmt.Println("Proxy Status: Unknown (could not read settings)")
+		} else {
+			if currentProxy != "" {
+				var tag string
+				// Extract IP from "IP:port"
+				proxyIP := strings.Split(currentProxy, ":")[0]
+
+				if gwErr == nil && proxyIP == gateway {
+					tag = " (Gateway)"
+				} else if proxyIP == "127.0.0.1" {
+					tag = " (Localhost)"
+				} else {
+					tag = " (Custom)"
+				}
+				fmt.Printf("Proxy Status: Active (%s)%s\n\n", currentProxy, tag)
+			} else {
+				fmt.Println("Proxy Status: Inactive\n")
+			}
+		}
+
+		fmt.Println("Select an option:")
+		fmt.Println("1. Set/Update Proxy")
+		fmt.Println("2. Unset Proxy")
+		fmt.Println("3. Edit Bypass List")
+		fmt.Println("4. Toggle WPAD Auto-Detect")
+		fmt.Println("5. Profile Manager")
+		fmt.Println("6. Exit")
+		fmt.Print("Enter your choice: ")
+
+		var choice int
+		_, err = fmt.Scanln(&choice)
+		if err != nil {
+			fmt.Println("Invalid input. Please enter a number.\n")
+			// Clear scanner buffer
+			var temp string
+			fmt.Scanln(&temp)
+			continue
+		}
+
+		switch choice {
+		case 1:
+			// Set/Update Proxy
+			var proxyServer string
+			// Use the gateway fetched earlier
+			if gwErr != nil {
+				fmt.Printf("Warning: Could not determine default gateway: %v\n", gwErr)
+				gateway = "unavailable"
+			}
+
+			fmt.Println("\nSelect proxy configuration:")
+			fmt.Printf("1. Default Gateway (%s:10808)\n", gateway)
+			fmt.Println("2. Localhost (127.0.0.1:10808)")
+			fmt.Println("3. Custom IP:Port")
+			fmt.Println("4. PAC URL")
+			fmt.Println("5. Back to main menu")
+			fmt.Print("Enter your choice: ")
+
+			var proxyChoice int
+			_, err = fmt.Scanln(&proxyChoice)
+			if err != nil {
+				fmt.Println("Invalid input. Please enter a number.\n")
+				continue
+			}
+
+			switch proxyChoice {
+			case 1:
+				if gateway == "unavailable" {
+					fmt.Println("Cannot use default gateway as it could not be determined. Please choose another option.")
+					continue
+				}
+				proxyServer = fmt.Sprintf("%s:10808", gateway)
+			case 2:
+				proxyServer = "127.0.0.1:10808"
+			case 3:
+				fmt.Print("Enter custom IP:Port (e.g., 192.168.1.1:8080): ")
+				_, err := fmt.Scanln(&proxyServer)
+				if err != nil || proxyServer == "" {
+					fmt.Println("Invalid input.")
+					continue
+				}
+			case 4:
+				// PAC URL configures Windows directly; it doesn't flow through
+				// the per-environment proxyServer path below.
+				fmt.Print("Enter PAC URL (e.g., http://proxy.example.com/proxy.pac): ")
+				var pacURL string
+				_, err := fmt.Scanln(&pacURL)
+				if err != nil || pacURL == "" {
+					fmt.Println("Invalid input.")
+					continue
+				}
+				if err := setPACURL(pacURL); err != nil {
+					fmt.Printf("Failed to set PAC URL: %v\n", err)
+				} else {
+					fmt.Printf("PAC URL set to: %s\n", pacURL)
+				}
+				continue
+			case 5:
+				continue // Go back to the main menu
+			default:
+				fmt.Println("Invalid choice. Please select a valid option.")
+				continue
+			}
+
+			fmt.Println("\nChecking proxy reachability...")
+			if warning, err := probeProxy(proxyServer); err != nil {
+				fmt.Printf("Proxy check failed: %v\n", err)
+				fmt.Print("Continue anyway? (y/n): ")
+				var answer string
+				fmt.Scanln(&answer)
+				if !strings.EqualFold(answer, "y") {
+					continue
+				}
+			} else if warning != "" {
+				fmt.Printf("Warning: %s\n", warning)
+			}
+
+			// Environment Selection
+			fmt.Println("\nüéØ Select environments to configure:")
+			fmt.Println("1. System Registry")
+			fmt.Println("2. PowerShell Profile")
+			fmt.Println("3. VS Code")
+			fmt.Println("4. npm")
+			fmt.Println("5. Git (g)")
+			fmt.Println("6. pip (p)")
+			fmt.Println("7. yarn (y)")
+			fmt.Println("8. WSL (w)")
+			fmt.Println("\nüìù Input options:")
+			fmt.Println("- Press ENTER or type 'A' for ALL environments")
+			fmt.Println("- Type numbers for specific environments (e.g., '13' for System + VS Code, '24' for PowerShell + npm)")
+			fmt.Println("- 5-8 also accept letter shortcuts (e.g., 'gw' for Git + WSL)")
+			fmt.Print("\nYour selection: ")
+
+			var envInput string
+			fmt.Scanln(&envInput)
+
+			selectedEnvs := parseEnvironmentSelection(envInput)
+			if selectedEnvs == 0 {
+				fmt.Println("No valid environments selected. Please try again.")
+				continue
+			}
+
+			fmt.Printf("\nEnter bypass list (NO_PROXY) [default: %s]: ", defaultBypassList)
+			var bypassList string
+			fmt.Scanln(&bypassList)
+			if bypassList == "" {
+				bypassList = defaultBypassList
+			}
+
+			fmt.Println()
+			displaySelectedEnvironments(selectedEnvs)
+			fmt.Printf("Setting proxy to: %s\n", proxyServer)
+
+			lastProfile = Profile{ProxyServer: proxyServer, Bypass: bypassList, EnvMask: selectedEnvs}
+			applyProxySettings(lastProfile, true)
+
+		case 2:
+			// Unset Proxy
+			fmt.Println("\nüéØ Select environments to clear proxy from:")
+			fmt.Println("1. System Registry")
+			fmt.Println("2. PowerShell Profile")
+			fmt.Println("3. VS Code")
+			fmt.Println("4. npm")
+			fmt.Println("5. Git (g)")
+			fmt.Println("6. pip (p)")
+			fmt.Println("7. yarn (y)")
+			fmt.Println("8. WSL (w)")
+			fmt.Println("\nüìù Input options:")
+			fmt.Println("- Press ENTER or type 'A' for ALL environments")
+			fmt.Println("- Type numbers for specific environments (e.g., '13' for System + VS Code, '24' for PowerShell + npm)")
+			fmt.Println("- 5-8 also accept letter shortcuts (e.g., 'gw' for Git + WSL)")
+			fmt.Print("\nYour selection: ")
+
+			var envInput string
+			fmt.Scanln(&envInput)
+
+			selectedEnvs := parseEnvironmentSelection(envInput)
+			if selectedEnvs == 0 {
+				fmt.Println("No valid environments selected. Please try again.")
+				continue
+			}
+
+			fmt.Println()
+			displaySelectedEnvironments(selectedEnvs)
+			fmt.Println("Clearing proxy settings...")
+
+			applyProxySettings(Profile{EnvMask: selectedEnvs}, false)
+
+		case 3:
+			// Edit Bypass List: re-applies the currently active proxy
+			// configuration with a new bypass list, without re-prompting
+			// for the proxy server or environment selection.
+			if lastProfile.EnvMask == 0 {
+				fmt.Println("No proxy configuration has been applied yet this session.")
+				continue
+			}
+
+			current := lastProfile.Bypass
+			if current == "" {
+				current = defaultBypassList
+			}
+			fmt.Printf("\nCurrent bypass list (NO_PROXY): %s\n", current)
+			fmt.Printf("Enter new bypass list [default: %s]: ", defaultBypassList)
+			var bypassList string
+			fmt.Scanln(&bypassList)
+			if bypassList == "" {
+				bypassList = defaultBypassList
+			}
+
+			lastProfile.Bypass = bypassList
+			displaySelectedEnvironments(lastProfile.EnvMask)
+			fmt.Printf("Setting proxy to: %s\n", lastProfile.ProxyServer)
+			applyProxySettings(lastProfile, true)
+
+		case 4:
+			fmt.Print("Enable WPAD auto-detect? (y/n): ")
+			var answer string
+			fmt.Scanln(&answer)
+			enable := strings.EqualFold(answer, "y")
+			if err := setWPADEnabled(enable); err != nil {
+				fmt.Printf("Failed to update WPAD auto-detect: %v\n", err)
+			} else if enable {
+				fmt.Println("WPAD auto-detect enabled.")
+			} else {
+				fmt.Println("WPAD auto-detect disabled.")
+			}
+
+		case 5:
+			if err := runProfileManager(&lastProfile); err != nil {
+				fmt.Printf("Profile Manager error: %v\n", err)
+			}
+
+		case 6:
+			fmt.Println("Exiting.")
+			return nil
+
+		default:
+			fmt.Println("Invalid choice. Please select a valid option.")
+		}
+		fmt.Println() // Add a newline for better spacing
+	}
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var listEnvsCmd = &cobra.Command{
+	Use:   "list-envs",
+	Short: "List the environment names accepted by --envs",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		envs := []struct {
+			name string
+			mask int
+		}{
+			{"system", ENV_SYSTEM}, {"powershell", ENV_POWERSHELL}, {"vscode", ENV_VSCODE}, {"npm", ENV_NPM},
+			{"git", ENV_GIT}, {"pip", ENV_PIP}, {"yarn", ENV_YARN}, {"wsl", ENV_WSL},
+		}
+		for _, env := range envs {
+			fmt.Printf("%s\t%s\n", env.name, envNames[env.mask])
+		}
+		fmt.Println("all\tevery environment above")
+		return nil
+	},
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Manage saved proxy profiles",
+}
+
+var (
+	profileSaveProxyFlag      string
+	profileSaveEnvsFlag       string
+	profileSaveUseGatewayFlag bool
+	profileSaveUseLocalhost   bool
+	profileSavePortFlag       int
+	profileSaveBypassFlag     string
+)
+
+var profileSaveCmd = &cobra.Command{
+	Use:   "save <name>",
+	Short: "Save the given proxy configuration as a named profile",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		proxyServer, err := resolveProxyServer(profileSaveProxyFlag, profileSaveUseGatewayFlag, profileSaveUseLocalhost, profileSavePortFlag)
+		if err != nil {
+			return err
+		}
+
+		envMask, err := parseEnvironmentNames(profileSaveEnvsFlag)
+		if err != nil {
+			return err
+		}
+
+		store, err := NewProfileStore()
+		if err != nil {
+			return err
+		}
+
+		profile := Profile{
+			Name:        args[0],
+			ProxyServer: proxyServer,
+			Bypass:      profileSaveBypassFlag,
+			EnvMask:     envMask,
+		}
+		if err := store.Put(profile); err != nil {
+			return err
+		}
+
+		fmt.Printf("Saved profile %q.\n", profile.Name)
+		return nil
+	},
+}
+
+var profileApplyForceFlag bool
+
+var profileApplyCmd = &cobra.Command{
+	Use:   "apply <name>",
+	Short: "Apply a saved profile",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store, err := NewProfileStore()
+		if err != nil {
+			return err
+		}
+
+		profile, ok, err := store.Get(args[0])
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("no profile named %q", args[0])
+		}
+
+		if !profileApplyForceFlag {
+			warning, err := probeProxy(profile.ProxyServer)
+			if err != nil {
+				return fmt.Errorf("proxy check failed (use --force to skip): %v", err)
+			}
+			if warning != "" {
+				fmt.Println("Warning:", warning)
+			}
+		}
+
+		displaySelectedEnvironments(profile.EnvMask)
+		fmt.Printf("Setting proxy to: %s\n", profile.ProxyServer)
+
+		_, failed := applyProxySettings(profile, true)
+		if len(failed) > 0 {
+			return fmt.Errorf("failed to set proxy for: %v", failed)
+		}
+		return nil
+	},
+}
+
+var profileListCmd = &cobra.Command{
+	Use:   "ls",
+	Short: "List saved profiles",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store, err := NewProfileStore()
+		if err != nil {
+			return err
+		}
+
+		profiles, err := store.List()
+		if err != nil {
+			return err
+		}
+		if len(profiles) == 0 {
+			fmt.Println("No profiles saved.")
+			return nil
+		}
+		for _, p := range profiles {
+			fmt.Printf("%s\t%s\n", p.Name, p.ProxyServer)
+		}
+		return nil
+	},
+}
+
+func init() {
+	profileSaveCmd.Flags().StringVar(&profileSaveProxyFlag, "proxy", "", "proxy server as host:port, or the per-protocol form http=host:port;https=host:port")
+	profileSaveCmd.Flags().StringVar(&profileSaveEnvsFlag, "envs", "all", "comma-separated environments to configure (system,powershell,vscode,npm,git,pip,yarn,wsl,all)")
+	profileSaveCmd.Flags().BoolVar(&profileSaveUseGatewayFlag, "use-gateway", false, "use the default gateway as the proxy host")
+	profileSaveCmd.Flags().BoolVar(&profileSaveUseLocalhost, "use-localhost", false, "use 127.0.0.1 as the proxy host")
+	profileSaveCmd.Flags().IntVar(&profileSavePortFlag, "port", 10808, "port to use with --use-gateway or --use-localhost")
+	profileSaveCmd.Flags().StringVar(&profileSaveBypassFlag, "bypass", defaultBypassList, "semicolon-separated bypass list (ProxyOverride/NO_PROXY)")
+
+	profileApplyCmd.Flags().BoolVar(&profileApplyForceFlag, "force", false, "skip the pre-apply proxy reachability check")
+
+	profileCmd.AddCommand(profileSaveCmd)
+	profileCmd.AddCommand(profileApplyCmd)
+	profileCmd.AddCommand(profileListCmd)
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// rootCmd is the base command. With no subcommand given it drops straight
+// into the interactive menu so existing muscle memory (`wpm`) keeps working.
+var rootCmd = &cobra.Command{
+	Use:   "wpm",
+	Short: "wpm manages the Windows proxy across system, shell and tool environments",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runInteractive()
+	},
+}
+
+// Execute runs the command tree and is called from main.
+func Execute() error {
+	return rootCmd.Execute()
+}
+
+func init() {
+	rootCmd.AddCommand(setCmd)
+	rootCmd.AddCommand(unsetCmd)
+	rootCmd.AddCommand(statusCmd)
+	rootCmd.AddCommand(listEnvsCmd)
+	rootCmd.AddCommand(interactiveCmd)
+	rootCmd.AddCommand(profileCmd)
+	rootCmd.AddCommand(checkCmd)
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	setProxyFlag      string
+	setEnvsFlag       string
+	setUseGatewayFlag bool
+	setUseLocalhost   bool
+	setPortFlag       int
+	setBypassFlag     string
+	setForceFlag      bool
+)
+
+var setCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Set or update the proxy for one or more environments",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		proxyServer, err := resolveProxyServer(setProxyFlag, setUseGatewayFlag, setUseLocalhost, setPortFlag)
+		if err != nil {
+			return err
+		}
+
+		if !setForceFlag {
+			warning, err := probeProxy(proxyServer)
+			if err != nil {
+				return fmt.Errorf("proxy check failed (use --force to skip): %v", err)
+			}
+			if warning != "" {
+				fmt.Println("Warning:", warning)
+			}
+		}
+
+		envMask, err := parseEnvironmentNames(setEnvsFlag)
+		if err != nil {
+			return err
+		}
+
+		displaySelectedEnvironments(envMask)
+		fmt.Printf("Setting proxy to: %s\n", proxyServer)
+
+		profile := Profile{ProxyServer: proxyServer, Bypass: setBypassFlag, EnvMask: envMask}
+		_, failed := applyProxySettings(profile, true)
+		if len(failed) > 0 {
+			return fmt.Errorf("failed to set proxy for: %v", failed)
+		}
+		return nil
+	},
+}
+
+// resolveProxyServer turns the --proxy/--use-gateway/--use-localhost/--port
+// flag combination into a proxy server string: host:port, or the --proxy
+// value as given (which may use the per-protocol form).
+func resolveProxyServer(proxy string, useGateway, useLocalhost bool, port int) (string, error) {
+	switch {
+	case proxy != "":
+		return proxy, nil
+	case useGateway:
+		gateway, err := getDefaultGateway()
+		if err != nil {
+			return "", fmt.Errorf("could not determine default gateway: %v", err)
+		}
+		return fmt.Sprintf("%s:%d", gateway, port), nil
+	case useLocalhost:
+		return fmt.Sprintf("127.0.0.1:%d", port), nil
+	default:
+		return "", fmt.Errorf("one of --proxy, --use-gateway, or --use-localhost is required")
+	}
+}
+
+func init() {
+	setCmd.Flags().StringVar(&setProxyFlag, "proxy", "", "proxy server as host:port, or the per-protocol form http=host:port;https=host:port")
+	setCmd.Flags().StringVar(&setEnvsFlag, "envs", "all", "comma-separated environments to configure (system,powershell,vscode,npm,git,pip,yarn,wsl,all)")
+	setCmd.Flags().BoolVar(&setUseGatewayFlag, "use-gateway", false, "use the default gateway as the proxy host")
+	setCmd.Flags().BoolVar(&setUseLocalhost, "use-localhost", false, "use 127.0.0.1 as the proxy host")
+	setCmd.Flags().IntVar(&setPortFlag, "port", 10808, "port to use with --use-gateway or --use-localhost")
+	setCmd.Flags().StringVar(&setBypassFlag, "bypass", defaultBypassList, "semicolon-separated bypass list (ProxyOverride/NO_PROXY)")
+	setCmd.Flags().BoolVar(&setForceFlag, "force", false, "skip the pre-apply proxy reachability check")
+}
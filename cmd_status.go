@@ -0,0 +1,200 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var statusJSONFlag bool
+
+// statusReport is the shape emitted by `wpm status --json` so the tool can be
+// consumed from PowerShell scripts and CI.
+type statusReport struct {
+	ProxyServer  string          `json:"proxyServer"`
+	Gateway      string          `json:"gateway,omitempty"`
+	GatewayErr   string          `json:"gatewayError,omitempty"`
+	PACURL       string          `json:"pacURL,omitempty"`
+	WPADEnabled  bool            `json:"wpadEnabled"`
+	Environments map[string]bool `json:"environments"`
+}
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the current proxy status across environments",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		report := buildStatusReport()
+
+		if statusJSONFlag {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(report)
+		}
+
+		if report.ProxyServer == "" {
+			fmt.Println("Proxy Status: Inactive")
+		} else {
+			fmt.Printf("Proxy Status: Active (%s)\n", report.ProxyServer)
+		}
+		if report.Gateway != "" {
+			fmt.Printf("Default Gateway: %s\n", report.Gateway)
+		}
+		if report.PACURL != "" {
+			fmt.Printf("PAC URL: %s\n", report.PACURL)
+		}
+		fmt.Printf("WPAD Auto-Detect: %v\n", report.WPADEnabled)
+		fmt.Println("\nDetected per-environment state:")
+		for env, detected := range report.Environments {
+			state := "not configured"
+			if detected {
+				state = "configured"
+			}
+			fmt.Printf("  - %s: %s\n", env, state)
+		}
+		return nil
+	},
+}
+
+// buildStatusReport gathers getCurrentProxy/getDefaultGateway plus
+// per-environment detected state into a single structured report.
+func buildStatusReport() statusReport {
+	report := statusReport{
+		Environments: make(map[string]bool),
+	}
+
+	if proxyServer, err := getCurrentProxy(); err == nil {
+		report.ProxyServer = proxyServer
+	}
+
+	if gateway, err := getDefaultGateway(); err == nil {
+		report.Gateway = gateway
+	} else {
+		report.GatewayErr = err.Error()
+	}
+
+	if ac, err := getAutoConfigState(); err == nil {
+		report.PACURL = ac.PACURL
+		report.WPADEnabled = ac.WPADEnabled
+	}
+
+	report.Environments[envNames[ENV_SYSTEM]] = report.ProxyServer != ""
+	report.Environments[envNames[ENV_POWERSHELL]] = detectPowerShellProxy()
+	report.Environments[envNames[ENV_VSCODE]] = detectVSCodeProxy()
+	report.Environments[envNames[ENV_NPM]] = detectNpmProxy()
+	report.Environments[envNames[ENV_GIT]] = detectGitProxy()
+	report.Environments[envNames[ENV_PIP]] = detectPipProxy()
+	report.Environments[envNames[ENV_YARN]] = detectYarnProxy()
+	report.Environments[envNames[ENV_WSL]] = detectWSLProxy()
+
+	return report
+}
+
+// detectPowerShellProxy reports whether the managed proxy block is present
+// in the user's PowerShell profile.
+func detectPowerShellProxy() bool {
+	profilePath := os.Getenv("USERPROFILE") + "\\Documents\\WindowsPowerShell\\Microsoft.PowerShell_profile.ps1"
+	content, err := os.ReadFile(profilePath)
+	if err != nil {
+		return false
+	}
+	return strings.Contains(string(content), "# Proxy Setting")
+}
+
+// detectVSCodeProxy reports whether VS Code's settings.json has http.proxy set.
+func detectVSCodeProxy() bool {
+	appData := os.Getenv("APPDATA")
+	settingsPath := filepath.Join(appData, "Code", "User", "settings.json")
+	data, err := os.ReadFile(settingsPath)
+	if err != nil {
+		return false
+	}
+	settings := make(map[string]interface{})
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return false
+	}
+	_, ok := settings["http.proxy"]
+	return ok
+}
+
+// detectNpmProxy reports whether .npmrc has a proxy= entry.
+func detectNpmProxy() bool {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return false
+	}
+	content, err := os.ReadFile(filepath.Join(homeDir, ".npmrc"))
+	if err != nil {
+		return false
+	}
+	for _, line := range strings.Split(string(content), "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "proxy=") {
+			return true
+		}
+	}
+	return false
+}
+
+// detectGitProxy reports whether git's global http.proxy is set.
+func detectGitProxy() bool {
+	out, err := exec.Command("git", "config", "--global", "--get", "http.proxy").Output()
+	if err != nil {
+		return false
+	}
+	return strings.TrimSpace(string(out)) != ""
+}
+
+// detectPipProxy reports whether pip.ini has a proxy entry under [global].
+func detectPipProxy() bool {
+	appData := os.Getenv("APPDATA")
+	content, err := os.ReadFile(filepath.Join(appData, "pip", "pip.ini"))
+	if err != nil {
+		return false
+	}
+	inGlobal := false
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "[") {
+			inGlobal = strings.EqualFold(line, "[global]")
+			continue
+		}
+		if inGlobal && strings.HasPrefix(line, "proxy") {
+			return true
+		}
+	}
+	return false
+}
+
+// detectYarnProxy reports whether yarn has a proxy configured.
+func detectYarnProxy() bool {
+	out, err := exec.Command("yarn", "config", "get", "proxy").Output()
+	if err != nil {
+		return false
+	}
+	value := strings.TrimSpace(string(out))
+	return value != "" && value != "undefined"
+}
+
+// detectWSLProxy reports whether any installed WSL distro has the managed
+// proxy block in its ~/.bashrc.
+func detectWSLProxy() bool {
+	distros, err := listWSLDistros()
+	if err != nil {
+		return false
+	}
+	for _, distro := range distros {
+		script := fmt.Sprintf("grep -q '%s' ~/.bashrc", wslProxyMarkerStart)
+		if err := exec.Command("wsl.exe", "-d", distro, "bash", "-c", script).Run(); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	statusCmd.Flags().BoolVar(&statusJSONFlag, "json", false, "emit status as structured JSON")
+}
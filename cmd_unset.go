@@ -0,0 +1,33 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var unsetEnvsFlag string
+
+var unsetCmd = &cobra.Command{
+	Use:   "unset",
+	Short: "Clear the proxy from one or more environments",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		envMask, err := parseEnvironmentNames(unsetEnvsFlag)
+		if err != nil {
+			return err
+		}
+
+		displaySelectedEnvironments(envMask)
+		fmt.Println("Clearing proxy settings...")
+
+		_, failed := applyProxySettings(Profile{EnvMask: envMask}, false)
+		if len(failed) > 0 {
+			return fmt.Errorf("failed to clear proxy for: %v", failed)
+		}
+		return nil
+	},
+}
+
+func init() {
+	unsetCmd.Flags().StringVar(&unsetEnvsFlag, "envs", "all", "comma-separated environments to clear (system,powershell,vscode,npm,git,pip,yarn,wsl,all)")
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"unicode/utf16"
+)
+
+// setGitProxy sets or clears http.proxy/https.proxy in the user's global git config.
+func setGitProxy(proxyServer string, enable bool) error {
+	if enable {
+		httpProxy, httpsProxy := resolveHTTP(proxyServer)
+		if err := exec.Command("git", "config", "--global", "http.proxy", "http://"+httpProxy).Run(); err != nil {
+			return fmt.Errorf("could not set git http.proxy: %v", err)
+		}
+		if err := exec.Command("git", "config", "--global", "https.proxy", "http://"+httpsProxy).Run(); err != nil {
+			return fmt.Errorf("could not set git https.proxy: %v", err)
+		}
+		return nil
+	}
+
+	for _, key := range []string{"http.proxy", "https.proxy"} {
+		cmd := exec.Command("git", "config", "--global", "--unset", key)
+		if err := cmd.Run(); err != nil {
+			// git config --unset exits with status 5 when the key isn't set.
+			if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 5 {
+				continue
+			}
+			return fmt.Errorf("could not unset git %s: %v", key, err)
+		}
+	}
+	return nil
+}
+
+// setPipProxy sets or clears the [global] proxy entry in %APPDATA%\pip\pip.ini.
+func setPipProxy(proxyServer string, enable bool) error {
+	appData := os.Getenv("APPDATA")
+	pipDir := filepath.Join(appData, "pip")
+	pipIniPath := filepath.Join(pipDir, "pip.ini")
+
+	if !enable {
+		if err := os.Remove(pipIniPath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("could not remove pip.ini: %v", err)
+		}
+		return nil
+	}
+
+	if err := os.MkdirAll(pipDir, 0755); err != nil {
+		return fmt.Errorf("could not create pip config directory: %v", err)
+	}
+
+	httpProxy, _ := resolveHTTP(proxyServer)
+	content := fmt.Sprintf("[global]\nproxy = http://%s\n", httpProxy)
+	if err := os.WriteFile(pipIniPath, []byte(content), 0644); err != nil {
+		return fmt.Errorf("could not write pip.ini: %v", err)
+	}
+
+	return nil
+}
+
+// setYarnProxy sets or clears the proxy/https-proxy entries via `yarn config`.
+func setYarnProxy(proxyServer string, enable bool) error {
+	if enable {
+		httpProxy, httpsProxy := resolveHTTP(proxyServer)
+		if err := exec.Command("yarn", "config", "set", "proxy", "http://"+httpProxy).Run(); err != nil {
+			return fmt.Errorf("could not set yarn proxy: %v", err)
+		}
+		if err := exec.Command("yarn", "config", "set", "https-proxy", "http://"+httpsProxy).Run(); err != nil {
+			return fmt.Errorf("could not set yarn https-proxy: %v", err)
+		}
+		return nil
+	}
+
+	if err := exec.Command("yarn", "config", "delete", "proxy").Run(); err != nil {
+		return fmt.Errorf("could not clear yarn proxy: %v", err)
+	}
+	if err := exec.Command("yarn", "config", "delete", "https-proxy").Run(); err != nil {
+		return fmt.Errorf("could not clear yarn https-proxy: %v", err)
+	}
+	return nil
+}
+
+// wslProxyMarkerStart/End delimit the managed block in each distro's ~/.bashrc,
+// mirroring the marker-based approach updatePowerShellProfile uses.
+const (
+	wslProxyMarkerStart = "# wpm proxy start"
+	wslProxyMarkerEnd   = "# wpm proxy end"
+)
+
+// setWSLProxy sets or clears HTTP_PROXY/HTTPS_PROXY exports in ~/.bashrc for
+// every installed WSL distro.
+func setWSLProxy(proxyServer string, enable bool) error {
+	distros, err := listWSLDistros()
+	if err != nil {
+		return err
+	}
+	if len(distros) == 0 {
+		return fmt.Errorf("no WSL distros found")
+	}
+
+	httpProxy, httpsProxy := resolveHTTP(proxyServer)
+
+	var errs []string
+	for _, distro := range distros {
+		if err := updateWSLBashrcProxy(distro, httpProxy, httpsProxy, enable); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", distro, err))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// listWSLDistros enumerates installed distros via `wsl.exe -l -q`, whose
+// output is UTF-16LE (with a leading BOM) rather than plain ASCII.
+func listWSLDistros() ([]string, error) {
+	output, err := exec.Command("wsl.exe", "-l", "-q").Output()
+	if err != nil {
+		return nil, fmt.Errorf("could not list WSL distros: %v", err)
+	}
+
+	var distros []string
+	for _, line := range strings.Split(decodeUTF16LE(output), "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" {
+			distros = append(distros, line)
+		}
+	}
+	return distros, nil
+}
+
+// decodeUTF16LE decodes UTF-16LE bytes as produced by native Windows tools
+// like wsl.exe, stripping a leading byte-order mark if present.
+func decodeUTF16LE(b []byte) string {
+	if len(b) >= 2 && b[0] == 0xFF && b[1] == 0xFE {
+		b = b[2:]
+	}
+	if len(b)%2 != 0 {
+		b = b[:len(b)-1]
+	}
+
+	u16 := make([]uint16, 0, len(b)/2)
+	for i := 0; i+1 < len(b); i += 2 {
+		u16 = append(u16, uint16(b[i])|uint16(b[i+1])<<8)
+	}
+
+	return string(utf16.Decode(u16))
+}
+
+// updateWSLBashrcProxy removes any existing managed block from the distro's
+// ~/.bashrc, then appends a fresh one if enabling.
+func updateWSLBashrcProxy(distro, httpProxy, httpsProxy string, enable bool) error {
+	removeScript := fmt.Sprintf("sed -i '/%s/,/%s/d' ~/.bashrc", wslProxyMarkerStart, wslProxyMarkerEnd)
+	if err := exec.Command("wsl.exe", "-d", distro, "bash", "-c", removeScript).Run(); err != nil {
+		return fmt.Errorf("could not clean existing proxy block: %v", err)
+	}
+
+	if !enable {
+		return nil
+	}
+
+	block := fmt.Sprintf(
+		"printf '%%s\\n' '%s' 'export HTTP_PROXY=\"http://%s\"' 'export HTTPS_PROXY=\"http://%s\"' '%s' >> ~/.bashrc",
+		wslProxyMarkerStart, httpProxy, httpsProxy, wslProxyMarkerEnd,
+	)
+	if err := exec.Command("wsl.exe", "-d", distro, "bash", "-c", block).Run(); err != nil {
+		return fmt.Errorf("could not append proxy block: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package main
+
+import "testing"
+
+func TestDecodeUTF16LE(t *testing.T) {
+	cases := []struct {
+		name string
+		in   []byte
+		want string
+	}{
+		{
+			name: "with BOM",
+			in:   []byte{0xFF, 0xFE, 'U', 0, 'b', 0, 'u', 0, 'n', 0, 't', 0, 'u', 0},
+			want: "Ubuntu",
+		},
+		{
+			name: "without BOM",
+			in:   []byte{'U', 0, 'b', 0, 'u', 0, 'n', 0, 't', 0, 'u', 0},
+			want: "Ubuntu",
+		},
+		{
+			name: "multiple lines",
+			in:   []byte{0xFF, 0xFE, 'A', 0, '\n', 0, 'B', 0},
+			want: "A\nB",
+		},
+		{
+			name: "empty",
+			in:   nil,
+			want: "",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := decodeUTF16LE(c.in); got != c.want {
+				t.Errorf("decodeUTF16LE(%v) = %q, want %q", c.in, got, c.want)
+			}
+		})
+	}
+}
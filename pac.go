@@ -0,0 +1,234 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	"golang.org/x/sys/windows"
+	"golang.org/x/sys/windows/registry"
+)
+
+// Bit layout of the binary DefaultConnectionSettings value (the same value
+// netsh/group policy and ieproxy read): a 4-byte header, a 4-byte counter,
+// a 4-byte flags field, followed by three length-prefixed strings
+// (ProxyServer, ProxyOverride, AutoConfigURL).
+const (
+	connFlagManualProxy = 1 << 1 // bit 1
+	connFlagPACURL      = 1 << 2 // bit 2
+	connFlagAutoDetect  = 1 << 3 // bit 3
+)
+
+const (
+	connectionSettingsKeyPath = `Software\Microsoft\Windows\CurrentVersion\Internet Settings\Connections`
+	connectionSettingsValue   = "DefaultConnectionSettings"
+)
+
+// connectionSettings mirrors the binary DefaultConnectionSettings registry value.
+type connectionSettings struct {
+	flags         uint32
+	proxyServer   string
+	proxyOverride string
+	autoConfigURL string
+}
+
+// parseConnectionSettings decodes the binary DefaultConnectionSettings value.
+func parseConnectionSettings(data []byte) *connectionSettings {
+	cs := &connectionSettings{}
+	if len(data) < 12 {
+		return cs
+	}
+
+	cs.flags = binary.LittleEndian.Uint32(data[8:12])
+
+	offset := 12
+	readString := func() string {
+		if offset+4 > len(data) {
+			return ""
+		}
+		n := int(binary.LittleEndian.Uint32(data[offset : offset+4]))
+		offset += 4
+		if n <= 0 || offset+n > len(data) {
+			return ""
+		}
+		s := string(data[offset : offset+n])
+		offset += n
+		return s
+	}
+
+	cs.proxyServer = readString()
+	cs.proxyOverride = readString()
+	cs.autoConfigURL = readString()
+	return cs
+}
+
+// bytes re-encodes the connectionSettings back into the binary registry layout.
+func (cs *connectionSettings) bytes() []byte {
+	var buf bytes.Buffer
+
+	var header [4]byte
+	binary.LittleEndian.PutUint32(header[:], 0x46)
+	buf.Write(header[:])
+
+	var counter [4]byte
+	binary.LittleEndian.PutUint32(counter[:], 1)
+	buf.Write(counter[:])
+
+	var flags [4]byte
+	binary.LittleEndian.PutUint32(flags[:], cs.flags)
+	buf.Write(flags[:])
+
+	writeString := func(s string) {
+		var n [4]byte
+		binary.LittleEndian.PutUint32(n[:], uint32(len(s)))
+		buf.Write(n[:])
+		buf.WriteString(s)
+	}
+	writeString(cs.proxyServer)
+	writeString(cs.proxyOverride)
+	writeString(cs.autoConfigURL)
+
+	return buf.Bytes()
+}
+
+// getConnectionSettings reads DefaultConnectionSettings, returning a zero
+// value if it hasn't been created yet.
+func getConnectionSettings() (*connectionSettings, error) {
+	k, err := registry.OpenKey(registry.CURRENT_USER, connectionSettingsKeyPath, registry.QUERY_VALUE)
+	if err != nil {
+		if err == registry.ErrNotExist {
+			return &connectionSettings{}, nil
+		}
+		return nil, err
+	}
+	defer k.Close()
+
+	data, _, err := k.GetBinaryValue(connectionSettingsValue)
+	if err != nil {
+		if err == registry.ErrNotExist {
+			return &connectionSettings{}, nil
+		}
+		return nil, err
+	}
+
+	return parseConnectionSettings(data), nil
+}
+
+// setConnectionSettings writes DefaultConnectionSettings back and tells
+// running applications to pick up the change without a logoff.
+func setConnectionSettings(cs *connectionSettings) error {
+	k, err := registry.OpenKey(registry.CURRENT_USER, connectionSettingsKeyPath, registry.QUERY_VALUE|registry.SET_VALUE)
+	if err != nil {
+		return err
+	}
+	defer k.Close()
+
+	if err := k.SetBinaryValue(connectionSettingsValue, cs.bytes()); err != nil {
+		return err
+	}
+
+	return refreshInternetSettings()
+}
+
+// setPACURL points AutoConfigURL at the given URL, both in the binary
+// DefaultConnectionSettings blob and the plain AutoConfigURL value that
+// wininet also honors. Passing an empty URL clears PAC configuration.
+func setPACURL(pacURL string) error {
+	cs, err := getConnectionSettings()
+	if err != nil {
+		return err
+	}
+
+	cs.autoConfigURL = pacURL
+	if pacURL != "" {
+		cs.flags |= connFlagPACURL
+	} else {
+		cs.flags &^= connFlagPACURL
+	}
+
+	if err := setConnectionSettings(cs); err != nil {
+		return err
+	}
+
+	k, err := registry.OpenKey(
+		registry.CURRENT_USER,
+		`Software\Microsoft\Windows\CurrentVersion\Internet Settings`,
+		registry.SET_VALUE,
+	)
+	if err != nil {
+		return err
+	}
+	defer k.Close()
+
+	if pacURL == "" {
+		if err := k.DeleteValue("AutoConfigURL"); err != nil && err != registry.ErrNotExist {
+			return err
+		}
+		return nil
+	}
+
+	return k.SetStringValue("AutoConfigURL", pacURL)
+}
+
+// setWPADEnabled flips the auto-detect bit (bit 3) of DefaultConnectionSettings.
+func setWPADEnabled(enable bool) error {
+	cs, err := getConnectionSettings()
+	if err != nil {
+		return err
+	}
+
+	if enable {
+		cs.flags |= connFlagAutoDetect
+	} else {
+		cs.flags &^= connFlagAutoDetect
+	}
+
+	return setConnectionSettings(cs)
+}
+
+// autoConfigState is the PAC/WPAD-related subset of DefaultConnectionSettings,
+// surfaced to `wpm status` alongside the plain ProxyServer/ProxyEnable state.
+type autoConfigState struct {
+	PACURL      string
+	WPADEnabled bool
+}
+
+// getAutoConfigState reads the current PAC URL and WPAD auto-detect flag.
+func getAutoConfigState() (autoConfigState, error) {
+	cs, err := getConnectionSettings()
+	if err != nil {
+		return autoConfigState{}, err
+	}
+
+	return autoConfigState{
+		PACURL:      cs.autoConfigURL,
+		WPADEnabled: cs.flags&connFlagAutoDetect != 0,
+	}, nil
+}
+
+const (
+	internetOptionSettingsChanged = 39
+	internetOptionRefresh         = 37
+)
+
+var (
+	modWinInet             = windows.NewLazySystemDLL("wininet.dll")
+	procInternetSetOptionW = modWinInet.NewProc("InternetSetOptionW")
+)
+
+// refreshInternetSettings notifies running applications (IE, WinHTTP, .NET)
+// that Internet Settings changed, so the new proxy/PAC/WPAD configuration
+// takes effect without requiring a logoff.
+func refreshInternetSettings() error {
+	ret, _, _ := procInternetSetOptionW.Call(0, internetOptionSettingsChanged, 0, 0)
+	if ret == 0 {
+		return fmt.Errorf("InternetSetOption(INTERNET_OPTION_SETTINGS_CHANGED) failed")
+	}
+
+	ret, _, _ = procInternetSetOptionW.Call(0, internetOptionRefresh, 0, 0)
+	if ret == 0 {
+		return fmt.Errorf("InternetSetOption(INTERNET_OPTION_REFRESH) failed")
+	}
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package main
+
+import "testing"
+
+func TestConnectionSettingsRoundTrip(t *testing.T) {
+	cases := []connectionSettings{
+		{},
+		{flags: connFlagManualProxy, proxyServer: "127.0.0.1:10808", proxyOverride: "localhost;127.0.0.1;<local>"},
+		{flags: connFlagPACURL, autoConfigURL: "http://proxy.example.com/proxy.pac"},
+		{flags: connFlagAutoDetect},
+		{
+			flags:         connFlagManualProxy | connFlagPACURL | connFlagAutoDetect,
+			proxyServer:   "10.0.0.1:8080",
+			proxyOverride: "localhost;127.0.0.1",
+			autoConfigURL: "http://proxy.example.com/proxy.pac",
+		},
+	}
+
+	for _, want := range cases {
+		got := parseConnectionSettings(want.bytes())
+		if *got != want {
+			t.Errorf("round-trip mismatch: got %+v, want %+v", *got, want)
+		}
+	}
+}
+
+func TestParseConnectionSettingsShortData(t *testing.T) {
+	for _, data := range [][]byte{nil, {}, {0x46, 0, 0}} {
+		if got := parseConnectionSettings(data); *got != (connectionSettings{}) {
+			t.Errorf("parseConnectionSettings(%v) = %+v, want zero value", data, *got)
+		}
+	}
+}
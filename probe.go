@@ -0,0 +1,58 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+const probeTimeout = 3 * time.Second
+
+// probeProxy checks that proxyServer is actually reachable and speaking
+// proxy protocol before the caller commits it to the registry/shell/VS
+// Code/npm. It dials the host:port and, on success, issues an HTTP CONNECT
+// for example.com:443. A dial/CONNECT failure is returned as an error (the
+// caller should fail fast); a non-200 CONNECT response is returned as a
+// warning string so the caller can decide whether to proceed.
+func probeProxy(proxyServer string) (warning string, err error) {
+	p := parseProxySpec(proxyServer)
+	host := p.HTTP
+	if host == "" {
+		host = p.HTTPS
+	}
+	if host == "" {
+		host = p.FTP
+	}
+	if host == "" {
+		host = p.Default
+	}
+	if host == "" {
+		host = proxyServer
+	}
+
+	conn, err := net.DialTimeout("tcp", host, probeTimeout)
+	if err != nil {
+		return "", fmt.Errorf("could not reach %s: %v", host, err)
+	}
+	defer conn.Close()
+
+	conn.SetDeadline(time.Now().Add(probeTimeout))
+
+	if _, err := fmt.Fprintf(conn, "CONNECT example.com:443 HTTP/1.1\r\nHost: example.com:443\r\n\r\n"); err != nil {
+		return "", fmt.Errorf("could not send CONNECT request to %s: %v", host, err)
+	}
+
+	statusLine, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil {
+		return "", fmt.Errorf("could not read response from %s: %v", host, err)
+	}
+	statusLine = strings.TrimSpace(statusLine)
+
+	if fields := strings.Fields(statusLine); len(fields) < 2 || fields[1] != "200" {
+		return fmt.Sprintf("%s did not respond with 200 to a test CONNECT (got %q); it may not be a working proxy", host, statusLine), nil
+	}
+
+	return "", nil
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// Profile is a saved proxy configuration: which server(s) to use, the bypass
+// list, and which environments to apply it to. applyProxySettings takes a
+// Profile so every entry point (interactive menu, CLI, saved profiles) goes
+// through the same path.
+type Profile struct {
+	Name        string `json:"name"`
+	ProxyServer string `json:"proxyServer"`
+	Bypass      string `json:"bypass"`
+	EnvMask     int    `json:"envMask"`
+}
+
+// ProfileStore persists named Profiles to %APPDATA%\wpm\profiles.json.
+type ProfileStore struct {
+	path string
+}
+
+// NewProfileStore creates the wpm config directory if needed and returns a
+// store pointed at profiles.json within it.
+func NewProfileStore() (*ProfileStore, error) {
+	appData := os.Getenv("APPDATA")
+	dir := filepath.Join(appData, "wpm")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("could not create wpm config directory: %v", err)
+	}
+	return &ProfileStore{path: filepath.Join(dir, "profiles.json")}, nil
+}
+
+// Load reads all saved profiles, returning an empty map if none exist yet.
+func (s *ProfileStore) Load() (map[string]Profile, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]Profile{}, nil
+		}
+		return nil, fmt.Errorf("could not read profiles: %v", err)
+	}
+
+	profiles := make(map[string]Profile)
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &profiles); err != nil {
+			return nil, fmt.Errorf("could not parse profiles: %v", err)
+		}
+	}
+	return profiles, nil
+}
+
+// Save writes all profiles back to disk.
+func (s *ProfileStore) Save(profiles map[string]Profile) error {
+	data, err := json.MarshalIndent(profiles, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(s.path, data, 0644); err != nil {
+		return fmt.Errorf("could not write profiles: %v", err)
+	}
+	return nil
+}
+
+// Get looks up a single profile by name.
+func (s *ProfileStore) Get(name string) (Profile, bool, error) {
+	profiles, err := s.Load()
+	if err != nil {
+		return Profile{}, false, err
+	}
+	p, ok := profiles[name]
+	return p, ok, nil
+}
+
+// Put saves or overwrites a profile.
+func (s *ProfileStore) Put(p Profile) error {
+	profiles, err := s.Load()
+	if err != nil {
+		return err
+	}
+	profiles[p.Name] = p
+	return s.Save(profiles)
+}
+
+// Delete removes a profile by name.
+func (s *ProfileStore) Delete(name string) error {
+	profiles, err := s.Load()
+	if err != nil {
+		return err
+	}
+	if _, ok := profiles[name]; !ok {
+		return fmt.Errorf("profile %q not found", name)
+	}
+	delete(profiles, name)
+	return s.Save(profiles)
+}
+
+// List returns all profiles sorted by name.
+func (s *ProfileStore) List() ([]Profile, error) {
+	profiles, err := s.Load()
+	if err != nil {
+		return nil, err
+	}
+	list := make([]Profile, 0, len(profiles))
+	for _, p := range profiles {
+		list = append(list, p)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list, nil
+}
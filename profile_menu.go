@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// runProfileManager drives the "Profile Manager" submenu of the interactive
+// loop. lastProfile is the most recently applied configuration, offered up
+// by "Save current as profile".
+func runProfileManager(lastProfile *Profile) error {
+	store, err := NewProfileStore()
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("\nProfile Manager:")
+	fmt.Println("1. Save current as profile")
+	fmt.Println("2. Apply profile")
+	fmt.Println("3. Delete profile")
+	fmt.Println("4. List profiles")
+	fmt.Println("5. Back to main menu")
+	fmt.Print("Enter your choice: ")
+
+	var choice int
+	if _, err := fmt.Scanln(&choice); err != nil {
+		fmt.Println("Invalid input. Please enter a number.")
+		return nil
+	}
+
+	switch choice {
+	case 1:
+		if lastProfile.EnvMask == 0 {
+			fmt.Println("No proxy configuration has been applied yet this session.")
+			return nil
+		}
+		fmt.Print("Enter a name for this profile: ")
+		var name string
+		fmt.Scanln(&name)
+		if name == "" {
+			fmt.Println("Invalid name.")
+			return nil
+		}
+		p := *lastProfile
+		p.Name = name
+		if err := store.Put(p); err != nil {
+			return err
+		}
+		fmt.Printf("Saved profile %q.\n", name)
+
+	case 2:
+		fmt.Print("Enter profile name to apply: ")
+		var name string
+		fmt.Scanln(&name)
+		p, ok, err := store.Get(name)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Printf("No profile named %q.\n", name)
+			return nil
+		}
+
+		fmt.Println("\nChecking proxy reachability...")
+		if warning, err := probeProxy(p.ProxyServer); err != nil {
+			fmt.Printf("Proxy check failed: %v\n", err)
+			fmt.Print("Continue anyway? (y/n): ")
+			var answer string
+			fmt.Scanln(&answer)
+			if !strings.EqualFold(answer, "y") {
+				return nil
+			}
+		} else if warning != "" {
+			fmt.Printf("Warning: %s\n", warning)
+		}
+
+		displaySelectedEnvironments(p.EnvMask)
+		fmt.Printf("Setting proxy to: %s\n", p.ProxyServer)
+		applyProxySettings(p, true)
+		*lastProfile = p
+
+	case 3:
+		fmt.Print("Enter profile name to delete: ")
+		var name string
+		fmt.Scanln(&name)
+		if err := store.Delete(name); err != nil {
+			return err
+		}
+		fmt.Printf("Deleted profile %q.\n", name)
+
+	case 4:
+		profiles, err := store.List()
+		if err != nil {
+			return err
+		}
+		if len(profiles) == 0 {
+			fmt.Println("No profiles saved.")
+			return nil
+		}
+		for _, p := range profiles {
+			fmt.Printf("  - %s: %s\n", p.Name, p.ProxyServer)
+		}
+
+	case 5:
+		return nil
+
+	default:
+		fmt.Println("Invalid choice. Please select a valid option.")
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package main
+
+import "strings"
+
+// protocolProxies holds the per-protocol proxy values parsed from the
+// Windows-style syntax used by IE/WinHTTP: "http=host:port;https=host:port;ftp=host:port",
+// with an optional trailing bare value meaning "catch-all" for any protocol
+// that wasn't given explicitly.
+type protocolProxies struct {
+	HTTP    string
+	HTTPS   string
+	FTP     string
+	Default string
+}
+
+// parseProxySpec parses the per-protocol proxy syntax. A plain "host:port"
+// with no "scheme=" prefixes is treated as a single catch-all value.
+func parseProxySpec(spec string) protocolProxies {
+	var p protocolProxies
+
+	for _, part := range strings.Split(spec, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		scheme, value, hasScheme := strings.Cut(part, "=")
+		if !hasScheme {
+			p.Default = part
+			continue
+		}
+
+		switch strings.ToLower(strings.TrimSpace(scheme)) {
+		case "http":
+			p.HTTP = strings.TrimSpace(value)
+		case "https":
+			p.HTTPS = strings.TrimSpace(value)
+		case "ftp":
+			p.FTP = strings.TrimSpace(value)
+		}
+	}
+
+	return p
+}
+
+// resolve returns the effective host:port for each protocol, falling back to
+// the catch-all Default when a protocol wasn't given explicitly.
+func (p protocolProxies) resolve() (httpProxy, httpsProxy, ftpProxy string) {
+	httpProxy = p.HTTP
+	if httpProxy == "" {
+		httpProxy = p.Default
+	}
+
+	httpsProxy = p.HTTPS
+	if httpsProxy == "" {
+		httpsProxy = p.Default
+	}
+
+	ftpProxy = p.FTP
+	if ftpProxy == "" {
+		ftpProxy = p.Default
+	}
+
+	return httpProxy, httpsProxy, ftpProxy
+}
+
+// resolveHTTP returns the effective http and https proxy legs, with each
+// falling back to the other when the spec only gave one of them and there's
+// no catch-all (e.g. "http=10.0.0.1:8080" alone). Most writers set both legs
+// unconditionally, so leaving one at "" would otherwise produce a malformed
+// value like "http://" instead of just reusing the leg that was given.
+func resolveHTTP(spec string) (httpProxy, httpsProxy string) {
+	httpProxy, httpsProxy, _ = parseProxySpec(spec).resolve()
+	if httpsProxy == "" {
+		httpsProxy = httpProxy
+	}
+	if httpProxy == "" {
+		httpProxy = httpsProxy
+	}
+	return httpProxy, httpsProxy
+}
+
+// defaultBypassList is used when the user doesn't specify one explicitly.
+const defaultBypassList = "localhost;127.0.0.1;<local>"
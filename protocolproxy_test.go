@@ -0,0 +1,90 @@
+package main
+
+import "testing"
+
+func TestParseProxySpec(t *testing.T) {
+	cases := []struct {
+		name string
+		spec string
+		want protocolProxies
+	}{
+		{
+			name: "bare host acts as catch-all",
+			spec: "10.0.0.1:8080",
+			want: protocolProxies{Default: "10.0.0.1:8080"},
+		},
+		{
+			name: "per-protocol legs with no catch-all",
+			spec: "http=10.0.0.1:8080;https=10.0.0.2:8443;ftp=10.0.0.3:21",
+			want: protocolProxies{HTTP: "10.0.0.1:8080", HTTPS: "10.0.0.2:8443", FTP: "10.0.0.3:21"},
+		},
+		{
+			name: "per-protocol legs plus trailing catch-all",
+			spec: "http=10.0.0.1:8080;10.0.0.9:9000",
+			want: protocolProxies{HTTP: "10.0.0.1:8080", Default: "10.0.0.9:9000"},
+		},
+		{
+			name: "missing leg with no catch-all",
+			spec: "https=10.0.0.2:8443",
+			want: protocolProxies{HTTPS: "10.0.0.2:8443"},
+		},
+		{
+			name: "empty spec",
+			spec: "",
+			want: protocolProxies{},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := parseProxySpec(c.spec); got != c.want {
+				t.Errorf("parseProxySpec(%q) = %+v, want %+v", c.spec, got, c.want)
+			}
+		})
+	}
+}
+
+func TestResolveHTTP(t *testing.T) {
+	cases := []struct {
+		name      string
+		spec      string
+		wantHTTP  string
+		wantHTTPS string
+	}{
+		{name: "catch-all fills both legs", spec: "10.0.0.1:8080", wantHTTP: "10.0.0.1:8080", wantHTTPS: "10.0.0.1:8080"},
+		{name: "both legs given", spec: "http=10.0.0.1:8080;https=10.0.0.2:8443", wantHTTP: "10.0.0.1:8080", wantHTTPS: "10.0.0.2:8443"},
+		{name: "https-only falls back to http", spec: "https=10.0.0.2:8443", wantHTTP: "10.0.0.2:8443", wantHTTPS: "10.0.0.2:8443"},
+		{name: "http-only falls back to https", spec: "http=10.0.0.1:8080", wantHTTP: "10.0.0.1:8080", wantHTTPS: "10.0.0.1:8080"},
+		{name: "empty spec yields empty legs", spec: "", wantHTTP: "", wantHTTPS: ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			gotHTTP, gotHTTPS := resolveHTTP(c.spec)
+			if gotHTTP != c.wantHTTP || gotHTTPS != c.wantHTTPS {
+				t.Errorf("resolveHTTP(%q) = (%q, %q), want (%q, %q)", c.spec, gotHTTP, gotHTTPS, c.wantHTTP, c.wantHTTPS)
+			}
+		})
+	}
+}
+
+func TestNpmrcNoProxyList(t *testing.T) {
+	cases := []struct {
+		name string
+		list string
+		want string
+	}{
+		{name: "strips <local>", list: "localhost;127.0.0.1;<local>", want: "localhost,127.0.0.1"},
+		{name: "no <local> present", list: "localhost;127.0.0.1", want: "localhost,127.0.0.1"},
+		{name: "empty list", list: "", want: ""},
+		{name: "only <local>", list: "<local>", want: ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := npmrcNoProxyList(c.list); got != c.want {
+				t.Errorf("npmrcNoProxyList(%q) = %q, want %q", c.list, got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,550 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"golang.org/x/sys/windows/registry"
+)
+
+// Environment constants
+const (
+	ENV_SYSTEM     = 1 << iota // 1
+	ENV_POWERSHELL             // 2
+	ENV_VSCODE                 // 4
+	ENV_NPM                    // 8
+	ENV_GIT                    // 16
+	ENV_PIP                    // 32
+	ENV_YARN                   // 64
+	ENV_WSL                    // 128
+)
+
+var envNames = map[int]string{
+	ENV_SYSTEM:     "System Registry",
+	ENV_POWERSHELL: "PowerShell Profile",
+	ENV_VSCODE:     "VS Code",
+	ENV_NPM:        "npm",
+	ENV_GIT:        "Git",
+	ENV_PIP:        "pip",
+	ENV_YARN:       "yarn",
+	ENV_WSL:        "WSL",
+}
+
+// allEnvs is every environment wpm knows how to configure.
+const allEnvs = ENV_SYSTEM | ENV_POWERSHELL | ENV_VSCODE | ENV_NPM | ENV_GIT | ENV_PIP | ENV_YARN | ENV_WSL
+
+// setVSCodeProxy sets or clears the proxy in VS Code's settings.json.
+// proxyServer accepts the per-protocol "http=host:port;https=host:port" syntax;
+// bypassList is semicolon-separated and populates http.noProxy as an array.
+func setVSCodeProxy(proxyServer, bypassList string, enable bool) error {
+	appData := os.Getenv("APPDATA")
+	settingsPath := filepath.Join(appData, "Code", "User", "settings.json")
+
+	settings := make(map[string]interface{})
+	if _, err := os.Stat(settingsPath); err == nil {
+		data, err := os.ReadFile(settingsPath)
+		if err == nil && len(data) > 0 {
+			_ = json.Unmarshal(data, &settings)
+		}
+	}
+
+	if enable {
+		httpProxy, httpsProxy := resolveHTTP(proxyServer)
+		settings["http.proxy"] = "http://" + httpProxy
+		if httpsProxy != "" && httpsProxy != httpProxy {
+			settings["https.proxy"] = "http://" + httpsProxy
+		}
+		if bypassList != "" {
+			settings["http.noProxy"] = strings.Split(bypassList, ";")
+		}
+	} else {
+		delete(settings, "http.proxy")
+		delete(settings, "https.proxy")
+		delete(settings, "http.noProxy")
+	}
+
+	// Write back the updated settings, preserving all other keys
+	file, err := os.OpenFile(settingsPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(settings); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// getDefaultGateway finds the default gateway IP address by parsing the output of the 'route print' command.
+func getDefaultGateway() (string, error) {
+	// Execute the command to print the IP routing table.
+	cmd := exec.Command("route", "print", "0.0.0.0")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("failed to execute route command: %v", err)
+	}
+
+	// Use a regular expression to find the gateway address for the default route (0.0.0.0).
+	re := regexp.MustCompile(`0.0.0.0\s+0.0.0.0\s+(\d+\.\d+\.\d+\.\d+)`)
+	matches := re.FindStringSubmatch(string(output))
+	if len(matches) < 2 {
+		return "", fmt.Errorf("gateway not found")
+	}
+
+	return matches[1], nil
+}
+
+// setProxySettings modifies the Windows Registry to enable/disable and set the
+// system proxy. proxyServer accepts either a plain "host:port" or the
+// per-protocol "http=host:port;https=host:port" syntax, written verbatim since
+// that's the same grammar Windows itself expects for ProxyServer.
+// bypassList is written to ProxyOverride (NO_PROXY equivalent).
+func setProxySettings(proxyServer, bypassList string, enable int) error {
+	// Open the necessary registry key with permissions to set values.
+	k, err := registry.OpenKey(
+		registry.CURRENT_USER,
+		`Software\Microsoft\Windows\CurrentVersion\Internet Settings`,
+		registry.QUERY_VALUE|registry.SET_VALUE,
+	)
+	if err != nil {
+		return err
+	}
+	defer k.Close()
+
+	// Set the proxy server address and port.
+	if err := k.SetStringValue("ProxyServer", proxyServer); err != nil {
+		return err
+	}
+
+	// Set the bypass list (ProxyOverride).
+	if err := k.SetStringValue("ProxyOverride", bypassList); err != nil {
+		return err
+	}
+
+	// Enable or disable the proxy.
+	if err := k.SetDWordValue("ProxyEnable", uint32(enable)); err != nil {
+		return err
+	}
+
+	// Keep the binary DefaultConnectionSettings value (read by IE/WinHTTP,
+	// and by netsh) in sync with the ProxyServer/ProxyEnable values above;
+	// otherwise the manual-proxy bit can go stale relative to the registry
+	// strings this function just wrote.
+	cs, err := getConnectionSettings()
+	if err != nil {
+		return err
+	}
+	cs.proxyServer = proxyServer
+	cs.proxyOverride = bypassList
+	if enable != 0 {
+		cs.flags |= connFlagManualProxy
+	} else {
+		cs.flags &^= connFlagManualProxy
+	}
+	if err := setConnectionSettings(cs); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// getCurrentProxy reads the current proxy server setting from the Windows Registry.
+func getCurrentProxy() (string, error) {
+	// Open the registry key with permissions to query values.
+	k, err := registry.OpenKey(
+		registry.CURRENT_USER,
+		`Software\Microsoft\Windows\CurrentVersion\Internet Settings`,
+		registry.QUERY_VALUE,
+	)
+	if err != nil {
+		return "", err
+	}
+	defer k.Close()
+
+	// Retrieve the "ProxyServer" string value.
+	proxyServer, _, err := k.GetStringValue("ProxyServer")
+	if err != nil && err != registry.ErrNotExist {
+		return "", err
+	}
+
+	return proxyServer, nil
+}
+
+// updatePowerShellProfile adds or removes proxy environment variables from the user's PowerShell profile.
+// This version uses a more robust method of removing the old block before adding a new one.
+// proxyServer accepts the per-protocol "http=host:port;https=host:port" syntax;
+// bypassList populates $env:NO_PROXY / $env:no_proxy.
+func updatePowerShellProfile(proxyServer, bypassList string, enable bool) error {
+	// Construct the path to the PowerShell profile.
+	profilePath := os.Getenv("USERPROFILE") + "\\Documents\\WindowsPowerShell\\Microsoft.PowerShell_profile.ps1"
+	if _, err := os.Stat(profilePath); os.IsNotExist(err) {
+		// Create the profile file if it doesn't exist.
+		file, err := os.Create(profilePath)
+		if err != nil {
+			return err
+		}
+		file.Close()
+	}
+
+	// Read the existing content of the profile.
+	fileContent, err := os.ReadFile(profilePath)
+	if err != nil {
+		return err
+	}
+
+	lines := strings.Split(string(fileContent), "\n")
+	var newLines []string
+
+	// First, remove any existing proxy block from the lines by filtering it out.
+	inBlock := false
+	for _, line := range lines {
+		// A line that starts with "# Proxy Setting" toggles whether we are in the block.
+		if strings.HasPrefix(line, "# Proxy Setting") {
+			inBlock = !inBlock
+			continue // Skip the marker lines themselves.
+		}
+		// Only add lines that are not inside a proxy block.
+		if !inBlock {
+			// Trim carriage returns that can linger on Windows
+			newLines = append(newLines, strings.TrimRight(line, "\r"))
+		}
+	}
+
+	// If enabling the proxy, add the new, correct block to the end.
+	if enable {
+		httpProxy, httpsProxy := resolveHTTP(proxyServer)
+		var noProxyLines string
+		if bypassList != "" {
+			noProxyLines = fmt.Sprintf("\n$env:NO_PROXY = \"%s\"\n$env:no_proxy = \"%s\"", bypassList, bypassList)
+		}
+		proxyBlock := fmt.Sprintf(
+			"# Proxy Setting\n$env:HTTP_PROXY = \"http://%s\"\n$env:HTTPS_PROXY = \"http://%s\"%s\n[System.Net.WebRequest]::DefaultWebProxy = New-Object System.Net.WebProxy($env:HTTP_PROXY)\n# Proxy Setting",
+			httpProxy, httpsProxy, noProxyLines,
+		)
+		// Add a newline for separation if the file isn't empty.
+		if len(newLines) > 0 && newLines[len(newLines)-1] != "" {
+			newLines = append(newLines, "")
+		}
+		newLines = append(newLines, proxyBlock)
+	}
+
+	// Join the processed lines and write them back to the file.
+	newContent := strings.Join(newLines, "\n")
+	err = os.WriteFile(profilePath, []byte(newContent), 0644)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// setNpmProxy sets or clears the proxy in the .npmrc file.
+// proxyServer accepts the per-protocol "http=host:port;https=host:port" syntax;
+// bypassList populates the noproxy= entry.
+func setNpmProxy(proxyServer, bypassList string, enable bool) error {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("could not get user home directory: %v", err)
+	}
+
+	npmrcPath := filepath.Join(homeDir, ".npmrc")
+
+	var lines []string
+
+	// Read existing .npmrc if it exists
+	if _, err := os.Stat(npmrcPath); err == nil {
+		content, err := os.ReadFile(npmrcPath)
+		if err != nil {
+			return fmt.Errorf("could not read .npmrc: %v", err)
+		}
+		lines = strings.Split(string(content), "\n")
+	}
+
+	// Remove existing proxy lines
+	var newLines []string
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if !strings.HasPrefix(trimmed, "proxy=") &&
+			!strings.HasPrefix(trimmed, "https-proxy=") &&
+			!strings.HasPrefix(trimmed, "noproxy=") &&
+			trimmed != "" {
+			newLines = append(newLines, strings.TrimRight(line, "\r"))
+		}
+	}
+
+	// Add proxy lines if enabling
+	if enable {
+		httpProxy, httpsProxy := resolveHTTP(proxyServer)
+		newLines = append(newLines, fmt.Sprintf("proxy=http://%s", httpProxy))
+		newLines = append(newLines, fmt.Sprintf("https-proxy=http://%s", httpsProxy))
+		if noProxy := npmrcNoProxyList(bypassList); noProxy != "" {
+			newLines = append(newLines, fmt.Sprintf("noproxy=%s", noProxy))
+		}
+	}
+
+	// Write back to .npmrc
+	content := strings.Join(newLines, "\n")
+	if len(newLines) > 0 {
+		content += "\n"
+	}
+
+	err = os.WriteFile(npmrcPath, []byte(content), 0644)
+	if err != nil {
+		return fmt.Errorf("could not write .npmrc: %v", err)
+	}
+
+	return nil
+}
+
+// npmrcNoProxyList converts a semicolon-delimited bypass list (the
+// ProxyOverride/NO_PROXY form used everywhere else) into the comma-separated
+// list npm's .npmrc noproxy expects, dropping the Windows-only "<local>"
+// token that npm doesn't understand.
+func npmrcNoProxyList(bypassList string) string {
+	var entries []string
+	for _, entry := range strings.Split(bypassList, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" || strings.EqualFold(entry, "<local>") {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return strings.Join(entries, ",")
+}
+
+// parseEnvironmentSelection parses user input for environment selection.
+// Environments 1-4 keep their original digits; 5-8 (Git, pip, yarn, WSL)
+// accept either their digit or a letter mnemonic (g/p/y/w) since a second
+// digit-only block would be easy to mistype against the first.
+func parseEnvironmentSelection(input string) int {
+	input = strings.TrimSpace(input)
+	if input == "" || strings.EqualFold(input, "a") {
+		return allEnvs
+	}
+
+	selectedEnvs := 0
+	for _, char := range input {
+		switch char {
+		case '1':
+			selectedEnvs |= ENV_SYSTEM
+		case '2':
+			selectedEnvs |= ENV_POWERSHELL
+		case '3':
+			selectedEnvs |= ENV_VSCODE
+		case '4':
+			selectedEnvs |= ENV_NPM
+		case '5', 'g', 'G':
+			selectedEnvs |= ENV_GIT
+		case '6', 'p', 'P':
+			selectedEnvs |= ENV_PIP
+		case '7', 'y', 'Y':
+			selectedEnvs |= ENV_YARN
+		case '8', 'w', 'W':
+			selectedEnvs |= ENV_WSL
+		}
+	}
+
+	return selectedEnvs
+}
+
+// parseEnvironmentNames parses a comma-separated environment list such as
+// "system,powershell,vscode,npm,git,pip,yarn,wsl,all" as used by the CLI --envs flag.
+func parseEnvironmentNames(input string) (int, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return 0, fmt.Errorf("no environments specified")
+	}
+
+	selectedEnvs := 0
+	for _, name := range strings.Split(input, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		switch name {
+		case "":
+			continue
+		case "all":
+			selectedEnvs |= allEnvs
+		case "system":
+			selectedEnvs |= ENV_SYSTEM
+		case "powershell":
+			selectedEnvs |= ENV_POWERSHELL
+		case "vscode":
+			selectedEnvs |= ENV_VSCODE
+		case "npm":
+			selectedEnvs |= ENV_NPM
+		case "git":
+			selectedEnvs |= ENV_GIT
+		case "pip":
+			selectedEnvs |= ENV_PIP
+		case "yarn":
+			selectedEnvs |= ENV_YARN
+		case "wsl":
+			selectedEnvs |= ENV_WSL
+		default:
+			return 0, fmt.Errorf("unknown environment %q", name)
+		}
+	}
+
+	if selectedEnvs == 0 {
+		return 0, fmt.Errorf("no valid environments specified")
+	}
+
+	return selectedEnvs, nil
+}
+
+// displaySelectedEnvironments shows which environments are selected
+func displaySelectedEnvironments(envMask int) {
+	if envMask == 0 {
+		fmt.Println("No environments selected.")
+		return
+	}
+
+	fmt.Print("Selected environments: ")
+	var selected []string
+
+	if envMask&ENV_SYSTEM != 0 {
+		selected = append(selected, envNames[ENV_SYSTEM])
+	}
+	if envMask&ENV_POWERSHELL != 0 {
+		selected = append(selected, envNames[ENV_POWERSHELL])
+	}
+	if envMask&ENV_VSCODE != 0 {
+		selected = append(selected, envNames[ENV_VSCODE])
+	}
+	if envMask&ENV_NPM != 0 {
+		selected = append(selected, envNames[ENV_NPM])
+	}
+	if envMask&ENV_GIT != 0 {
+		selected = append(selected, envNames[ENV_GIT])
+	}
+	if envMask&ENV_PIP != 0 {
+		selected = append(selected, envNames[ENV_PIP])
+	}
+	if envMask&ENV_YARN != 0 {
+		selected = append(selected, envNames[ENV_YARN])
+	}
+	if envMask&ENV_WSL != 0 {
+		selected = append(selected, envNames[ENV_WSL])
+	}
+
+	fmt.Println(strings.Join(selected, ", "))
+}
+
+// applyProxySettings applies a Profile's proxy settings to its selected
+// environments and reports per-environment outcome. It is the single entry
+// point used by the interactive menu, the CLI subcommands, and saved
+// profiles, and returns the list of environments that failed so callers can
+// decide how to exit/report.
+func applyProxySettings(profile Profile, enable bool) (success []string, failed []string) {
+	proxyServer := profile.ProxyServer
+	bypassList := profile.Bypass
+	envMask := profile.EnvMask
+
+	var errors []string
+
+	if envMask&ENV_SYSTEM != 0 {
+		var enableInt int
+		if enable {
+			enableInt = 1
+		}
+		if err := setProxySettings(proxyServer, bypassList, enableInt); err != nil {
+			errors = append(errors, fmt.Sprintf("System Registry: %v", err))
+			failed = append(failed, "System Registry")
+		} else {
+			success = append(success, "System Registry")
+		}
+	}
+
+	if envMask&ENV_POWERSHELL != 0 {
+		if err := updatePowerShellProfile(proxyServer, bypassList, enable); err != nil {
+			errors = append(errors, fmt.Sprintf("PowerShell Profile: %v", err))
+			failed = append(failed, "PowerShell Profile")
+		} else {
+			success = append(success, "PowerShell Profile")
+		}
+	}
+
+	if envMask&ENV_VSCODE != 0 {
+		if err := setVSCodeProxy(proxyServer, bypassList, enable); err != nil {
+			errors = append(errors, fmt.Sprintf("VS Code: %v", err))
+			failed = append(failed, "VS Code")
+		} else {
+			success = append(success, "VS Code")
+		}
+	}
+
+	if envMask&ENV_NPM != 0 {
+		if err := setNpmProxy(proxyServer, bypassList, enable); err != nil {
+			errors = append(errors, fmt.Sprintf("npm: %v", err))
+			failed = append(failed, "npm")
+		} else {
+			success = append(success, "npm")
+		}
+	}
+
+	if envMask&ENV_GIT != 0 {
+		if err := setGitProxy(proxyServer, enable); err != nil {
+			errors = append(errors, fmt.Sprintf("Git: %v", err))
+			failed = append(failed, "Git")
+		} else {
+			success = append(success, "Git")
+		}
+	}
+
+	if envMask&ENV_PIP != 0 {
+		if err := setPipProxy(proxyServer, enable); err != nil {
+			errors = append(errors, fmt.Sprintf("pip: %v", err))
+			failed = append(failed, "pip")
+		} else {
+			success = append(success, "pip")
+		}
+	}
+
+	if envMask&ENV_YARN != 0 {
+		if err := setYarnProxy(proxyServer, enable); err != nil {
+			errors = append(errors, fmt.Sprintf("yarn: %v", err))
+			failed = append(failed, "yarn")
+		} else {
+			success = append(success, "yarn")
+		}
+	}
+
+	if envMask&ENV_WSL != 0 {
+		if err := setWSLProxy(proxyServer, enable); err != nil {
+			errors = append(errors, fmt.Sprintf("WSL: %v", err))
+			failed = append(failed, "WSL")
+		} else {
+			success = append(success, "WSL")
+		}
+	}
+
+	// Display results
+	if len(success) > 0 {
+		action := "set"
+		if !enable {
+			action = "cleared"
+		}
+		fmt.Printf("‚úì Proxy %s successfully for: %s\n", action, strings.Join(success, ", "))
+	}
+
+	if len(errors) > 0 {
+		fmt.Println("\n‚ö† Errors occurred:")
+		for _, err := range errors {
+			fmt.Printf("  - %s\n", err)
+		}
+	}
+
+	if envMask&ENV_POWERSHELL != 0 {
+		fmt.Println("\nIMPORTANT: You must open a new PowerShell window for changes to take effect.")
+	}
+
+	return success, failed
+}